@@ -0,0 +1,181 @@
+package fcm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// legacyEndpoint is the FCM legacy HTTP API endpoint.
+const legacyEndpoint = "https://fcm.googleapis.com/fcm/send"
+
+// Client sends messages to the FCM legacy HTTP API using a server API
+// key.
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+
+	onTokenInvalidated TokenInvalidatedFunc
+	tokenStore         TokenStore
+}
+
+// TokenInvalidatedFunc is called for each Result that reports a device
+// token as no longer valid, or that supplies a canonical replacement
+// token in Result.RegistrationID. newToken is empty unless FCM supplied
+// a replacement.
+type TokenInvalidatedFunc func(oldToken, newToken string, reason error)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithEndpoint overrides the default FCM endpoint. Useful for testing
+// against a mock server.
+func WithEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		c.endpoint = endpoint
+	}
+}
+
+// WithHTTPClient overrides the default http.Client used to send
+// requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokenInvalidatedFunc registers fn to be called, after every Send
+// and SendWithRetry(Config) call, for each registration token the
+// response reported as invalid or replaced.
+func WithTokenInvalidatedFunc(fn TokenInvalidatedFunc) Option {
+	return func(c *Client) {
+		c.onTokenInvalidated = fn
+	}
+}
+
+// WithTokenStore registers store to be updated, alongside
+// onTokenInvalidated, for each registration token the response
+// reported as invalid or replaced.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// NewClient creates a Client that authenticates with the given FCM
+// server API key.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		apiKey:     apiKey,
+		endpoint:   legacyEndpoint,
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Send posts msg to the FCM legacy HTTP API and returns the decoded
+// Response.
+func (c *Client) Send(msg *Message) (*Response, error) {
+	resp, _, err := c.send(msg)
+	if err == nil {
+		c.notifyInvalidTokens(targetIDs(msg), resp)
+	}
+	return resp, err
+}
+
+// targetIDs returns the registration IDs msg was sent to, treating a
+// single-target message (To) as a one-element multicast.
+func targetIDs(msg *Message) []string {
+	if len(msg.RegistrationIDs) > 0 {
+		return msg.RegistrationIDs
+	}
+	return []string{msg.To}
+}
+
+// notifyInvalidTokens walks resp.Results, pairing each with the
+// registration ID it was sent to, and calls onTokenInvalidated and
+// tokenStore for every token FCM reported as invalid or replaced.
+func (c *Client) notifyInvalidTokens(ids []string, resp *Response) {
+	if resp == nil || (c.onTokenInvalidated == nil && c.tokenStore == nil) {
+		return
+	}
+
+	for i, result := range resp.Results {
+		if i >= len(ids) {
+			break
+		}
+
+		switch {
+		case result.Unregistered():
+			c.invalidateToken(ids[i], "", result.Error)
+		case result.RegistrationID != "":
+			c.invalidateToken(ids[i], result.RegistrationID, nil)
+		}
+	}
+}
+
+func (c *Client) invalidateToken(oldToken, newToken string, reason error) {
+	if c.tokenStore != nil {
+		if newToken != "" {
+			c.tokenStore.Replace(oldToken, newToken)
+		} else {
+			c.tokenStore.MarkInvalid(oldToken, reason)
+		}
+	}
+	if c.onTokenInvalidated != nil {
+		c.onTokenInvalidated(oldToken, newToken, reason)
+	}
+}
+
+// send is the shared implementation behind Send, also returning the
+// raw response headers so callers that need them (such as a retry
+// loop reading Retry-After) don't have to re-issue the request.
+func (c *Client) send(msg *Message) (*Response, http.Header, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "key="+c.apiKey)
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, connectionError(err.Error())
+	}
+	defer httpResp.Body.Close()
+
+	switch {
+	case httpResp.StatusCode == http.StatusTooManyRequests:
+		return nil, httpResp.Header, rateLimitError(fmt.Sprintf("rate limited: %s", httpResp.Status))
+	case httpResp.StatusCode >= 500:
+		return nil, httpResp.Header, serverError(fmt.Sprintf("server error: %s", httpResp.Status))
+	case httpResp.StatusCode == http.StatusUnauthorized:
+		return nil, httpResp.Header, fmt.Errorf("fcm: invalid API key")
+	case httpResp.StatusCode >= 400:
+		return nil, httpResp.Header, fmt.Errorf("fcm: request rejected: %s", httpResp.Status)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, httpResp.Header, err
+	}
+
+	setHTTPStatus(resp.Error, httpResp.StatusCode)
+	for i := range resp.Results {
+		setHTTPStatus(resp.Results[i].Error, httpResp.StatusCode)
+	}
+
+	return &resp, httpResp.Header, nil
+}
@@ -0,0 +1,33 @@
+package fcm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendPopulatesFCMErrorHTTPStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]string{{"error": "Unavailable"}},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", WithEndpoint(srv.URL))
+	resp, err := client.Send(&Message{To: "token"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var fe *FCMError
+	if !errors.As(resp.Results[0].Error, &fe) {
+		t.Fatalf("Results[0].Error = %v, want *FCMError", resp.Results[0].Error)
+	}
+	if fe.HTTPStatus != http.StatusOK {
+		t.Fatalf("HTTPStatus = %d, want %d", fe.HTTPStatus, http.StatusOK)
+	}
+}
@@ -0,0 +1,232 @@
+package fcm
+
+import "errors"
+
+var (
+	// ErrMissingRegistration occurs if registration token is not set.
+	ErrMissingRegistration = errors.New("MissingRegistration")
+
+	// ErrInvalidRegistration occurs if registration token is invalid.
+	ErrInvalidRegistration = errors.New("InvalidRegistration")
+
+	// ErrNotRegistered occurs when application was deleted from device and
+	// token is not registered in FCM.
+	ErrNotRegistered = errors.New("NotRegistered")
+
+	// ErrInvalidPackageName occurs if package name in message is invalid.
+	ErrInvalidPackageName = errors.New("InvalidPackageName")
+
+	// ErrMismatchSenderID occurs when application has a new registration token.
+	ErrMismatchSenderID = errors.New("MismatchSenderId")
+
+	// ErrMessageTooBig occurs when message is too big.
+	ErrMessageTooBig = errors.New("MessageTooBig")
+
+	// ErrInvalidDataKey occurs if data key is invalid.
+	ErrInvalidDataKey = errors.New("InvalidDataKey")
+
+	// ErrInvalidTTL occurs when message has invalid TTL.
+	ErrInvalidTTL = errors.New("InvalidTTL")
+
+	// ErrUnavailable occurs when FCM service is unavailable. It makes sense
+	// to retry after this error.
+	ErrUnavailable = connectionError("Unavailable")
+
+	// ErrInternalServerError is internal FCM error. It makes sense to retry
+	// after this error.
+	ErrInternalServerError = serverError("InternalServerError")
+
+	// ErrDeviceMessageRateExceeded occurs when client sent to many requests to
+	// the device.
+	ErrDeviceMessageRateExceeded = errors.New("DeviceMessageRateExceeded")
+
+	// ErrTopicsMessageRateExceeded occurs when client sent to many requests to
+	// the topics.
+	ErrTopicsMessageRateExceeded = errors.New("TopicsMessageRateExceeded")
+
+	// ErrInvalidParameters occurs when provided parameters have the right name and type
+	ErrInvalidParameters = errors.New("InvalidParameters")
+
+	// ErrUnknown is returned for an error code this package doesn't
+	// recognize. Use errors.As to recover the raw code via FCMError.Code.
+	ErrUnknown = errors.New("Unknown")
+
+	// ErrInvalidApnsCredential for Invalid APNs credentials
+	ErrInvalidApnsCredential = errors.New("InvalidApnsCredential")
+
+	// ErrInvalidArgument occurs when the v1 API rejects a message
+	// because a field has an invalid value. It has no legacy API
+	// equivalent.
+	ErrInvalidArgument = errors.New("InvalidArgument")
+)
+
+var (
+	errMap = map[string]error{
+		"MissingRegistration":       ErrMissingRegistration,
+		"InvalidRegistration":       ErrInvalidRegistration,
+		"NotRegistered":             ErrNotRegistered,
+		"InvalidPackageName":        ErrInvalidPackageName,
+		"MismatchSenderId":          ErrMismatchSenderID,
+		"MessageTooBig":             ErrMessageTooBig,
+		"InvalidDataKey":            ErrInvalidDataKey,
+		"InvalidTtl":                ErrInvalidTTL,
+		"Unavailable":               ErrUnavailable,
+		"InternalServerError":       ErrInternalServerError,
+		"DeviceMessageRateExceeded": ErrDeviceMessageRateExceeded,
+		"TopicsMessageRateExceeded": ErrTopicsMessageRateExceeded,
+		"InvalidParameters":         ErrInvalidParameters,
+		"InvalidApnsCredential":     ErrInvalidApnsCredential,
+
+		// FCM HTTP v1 API canonical error codes, from error.details[].errorCode.
+		"UNREGISTERED":           ErrNotRegistered,
+		"SENDER_ID_MISMATCH":     ErrMismatchSenderID,
+		"QUOTA_EXCEEDED":         ErrDeviceMessageRateExceeded,
+		"UNAVAILABLE":            ErrUnavailable,
+		"INTERNAL":               ErrInternalServerError,
+		"THIRD_PARTY_AUTH_ERROR": ErrInvalidApnsCredential,
+		"INVALID_ARGUMENT":       ErrInvalidArgument,
+	}
+)
+
+// ErrorDetail is one entry of the v1 API's error.details array.
+type ErrorDetail struct {
+	Type      string `json:"@type"`
+	ErrorCode string `json:"errorCode"`
+}
+
+// FCMError carries the structured details of an FCM error response. It
+// matches the sentinel error it wraps under errors.Is, so
+// errors.Is(err, fcm.ErrNotRegistered) keeps working, while
+// errors.As(err, &fcmErr) exposes the raw code, HTTP status, message,
+// and v1 error.details. A direct comparison such as
+// err == fcm.ErrNotRegistered does not match an *FCMError.
+type FCMError struct {
+	// Code is the raw error code FCM returned, e.g. "NotRegistered" or
+	// "UNREGISTERED". For unrecognized codes this is the verbatim
+	// value FCM sent, even though Is/As resolve to ErrUnknown.
+	Code string
+
+	// HTTPStatus is the HTTP status code of the response that carried
+	// this error, or 0 if unknown.
+	HTTPStatus int
+
+	// Message is the human-readable message FCM returned, if any.
+	Message string
+
+	// Details holds the v1 API's error.details entries. Empty for the
+	// legacy API, which doesn't report them.
+	Details []ErrorDetail
+
+	sentinel error
+}
+
+// newFCMError resolves code against errMap, falling back to ErrUnknown
+// for codes this package doesn't recognize.
+func newFCMError(code string, httpStatus int, message string, details []ErrorDetail) *FCMError {
+	sentinel, ok := errMap[code]
+	if !ok {
+		sentinel = ErrUnknown
+	}
+
+	return &FCMError{
+		Code:       code,
+		HTTPStatus: httpStatus,
+		Message:    message,
+		Details:    details,
+		sentinel:   sentinel,
+	}
+}
+
+// NewError builds a structured *FCMError for the given FCM error code.
+// The fcmv1 subpackage uses this to turn error.details[].errorCode into
+// the same sentinels ErrorFromCode produces, plus the structured data
+// this package's own decoding can't capture (HTTP status, message).
+func NewError(code string, httpStatus int, message string, details []ErrorDetail) *FCMError {
+	return newFCMError(code, httpStatus, message, details)
+}
+
+// ErrorFromCode maps a legacy or v1 FCM error code string to one of
+// this package's sentinel errors, returning ErrUnknown for codes it
+// doesn't recognize.
+func ErrorFromCode(code string) error {
+	if val, ok := errMap[code]; ok {
+		return val
+	}
+	return ErrUnknown
+}
+
+// setHTTPStatus backfills HTTPStatus on an error decoded by
+// Response.UnmarshalJSON or Result.UnmarshalJSON, which only see the
+// response body and not the status code it arrived with.
+func setHTTPStatus(err error, httpStatus int) {
+	if fe, ok := err.(*FCMError); ok {
+		fe.HTTPStatus = httpStatus
+	}
+}
+
+func (e *FCMError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code
+}
+
+// Is reports whether target is the sentinel error this FCMError
+// resolves to, so errors.Is(err, fcm.ErrNotRegistered) keeps working.
+func (e *FCMError) Is(target error) bool {
+	return e.sentinel == target
+}
+
+// Unwrap returns the sentinel error this FCMError resolves to.
+func (e *FCMError) Unwrap() error {
+	return e.sentinel
+}
+
+// connectionError represents connection errors such as timeout error, etc.
+// Implements `net.Error` interface.
+type connectionError string
+
+func (err connectionError) Error() string {
+	return string(err)
+}
+
+func (err connectionError) Temporary() bool {
+	return true
+}
+
+func (err connectionError) Timeout() bool {
+	return true
+}
+
+// serverError represents internal server errors.
+// Implements `net.Error` interface.
+type serverError string
+
+func (err serverError) Error() string {
+	return string(err)
+}
+
+func (serverError) Temporary() bool {
+	return true
+}
+
+func (serverError) Timeout() bool {
+	return false
+}
+
+// rateLimitError represents an HTTP 429 response. Implements
+// `net.Error` interface so it's picked up by the same Temporary()
+// check as connectionError and serverError.
+type rateLimitError string
+
+func (err rateLimitError) Error() string {
+	return string(err)
+}
+
+func (rateLimitError) Temporary() bool {
+	return true
+}
+
+func (rateLimitError) Timeout() bool {
+	return false
+}
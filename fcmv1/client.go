@@ -0,0 +1,129 @@
+package fcmv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/evrenios/go-fcm"
+)
+
+// sendURLFormat is the FCM HTTP v1 send endpoint, templated on the
+// Firebase project ID.
+const sendURLFormat = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+
+// scope is the OAuth2 scope required to call the v1 send endpoint.
+const scope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// Client sends messages to the FCM HTTP v1 API, authenticating with an
+// OAuth2 access token derived from a service-account credentials file.
+type Client struct {
+	endpoint   string
+	tokenSrc   oauth2.TokenSource
+	httpClient *http.Client
+
+	onTokenInvalidated fcm.TokenInvalidatedFunc
+	tokenStore         fcm.TokenStore
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient overrides the default http.Client used to send
+// requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTokenInvalidatedFunc registers fn to be called, after every Send
+// call, when the response reports msg.Token as invalid.
+func WithTokenInvalidatedFunc(fn fcm.TokenInvalidatedFunc) Option {
+	return func(c *Client) {
+		c.onTokenInvalidated = fn
+	}
+}
+
+// WithTokenStore registers store to be updated, alongside
+// onTokenInvalidated, when the response reports msg.Token as invalid.
+func WithTokenStore(store fcm.TokenStore) Option {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// NewClient creates a Client for the given Firebase project, using the
+// service-account credentials JSON in credentialsJSON to obtain and
+// automatically refresh OAuth2 access tokens.
+func NewClient(ctx context.Context, projectID string, credentialsJSON []byte, opts ...Option) (*Client, error) {
+	creds, err := google.CredentialsFromJSON(ctx, credentialsJSON, scope)
+	if err != nil {
+		return nil, fmt.Errorf("fcmv1: parse credentials: %w", err)
+	}
+
+	c := &Client{
+		endpoint:   fmt.Sprintf(sendURLFormat, projectID),
+		tokenSrc:   oauth2.ReuseTokenSource(nil, creds.TokenSource),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Send posts msg to the FCM v1 endpoint and decodes the response into a
+// Result.
+func (c *Client) Send(ctx context.Context, msg *Message) (*Result, error) {
+	token, err := c.tokenSrc.Token()
+	if err != nil {
+		return nil, fmt.Errorf("fcmv1: obtain access token: %w", err)
+	}
+
+	body, err := json.Marshal(struct {
+		Message *Message `json:"message"`
+	}{Message: msg})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	result := &Result{StatusCode: httpResp.StatusCode}
+	if err := json.NewDecoder(httpResp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("fcmv1: decode response: %w", err)
+	}
+	if fe, ok := result.Error.(*fcm.FCMError); ok {
+		fe.HTTPStatus = httpResp.StatusCode
+	}
+
+	if msg.Token != "" && result.Unregistered() {
+		if c.tokenStore != nil {
+			c.tokenStore.MarkInvalid(msg.Token, result.Error)
+		}
+		if c.onTokenInvalidated != nil {
+			c.onTokenInvalidated(msg.Token, "", result.Error)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package fcmv1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/evrenios/go-fcm"
+)
+
+func newTestClient(endpoint string, opts ...Option) *Client {
+	c := &Client{
+		endpoint:   endpoint,
+		tokenSrc:   oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func TestClientSendSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"name": "projects/p/messages/1"})
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv.URL)
+	result, err := client.Send(context.Background(), &Message{Token: "token"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if result.Name != "projects/p/messages/1" {
+		t.Fatalf("Name = %q, want %q", result.Name, "projects/p/messages/1")
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Fatalf("StatusCode = %d, want %d", result.StatusCode, http.StatusOK)
+	}
+}
+
+func TestClientSendPopulatesHTTPStatusAndInvalidatesToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    404,
+				"status":  "NOT_FOUND",
+				"message": "Requested entity was not found.",
+				"details": []map[string]string{
+					{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	var store fcm.MemoryTokenStore
+	var invalidated []string
+	client := newTestClient(srv.URL,
+		WithTokenStore(&store),
+		WithTokenInvalidatedFunc(func(oldToken, newToken string, reason error) {
+			invalidated = append(invalidated, oldToken)
+		}),
+	)
+
+	result, err := client.Send(context.Background(), &Message{Token: "dead-token"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if !result.Unregistered() {
+		t.Fatalf("Unregistered() = false, want true")
+	}
+
+	var fe *fcm.FCMError
+	if !errors.As(result.Error, &fe) {
+		t.Fatalf("Error = %v, want *fcm.FCMError", result.Error)
+	}
+	if fe.HTTPStatus != http.StatusNotFound {
+		t.Fatalf("HTTPStatus = %d, want %d", fe.HTTPStatus, http.StatusNotFound)
+	}
+
+	if invalid := store.Invalid(); len(invalid) != 1 || invalid[0] != "dead-token" {
+		t.Fatalf("store.Invalid() = %v, want [dead-token]", invalid)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "dead-token" {
+		t.Fatalf("onTokenInvalidated calls = %v, want [dead-token]", invalidated)
+	}
+}
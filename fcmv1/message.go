@@ -0,0 +1,32 @@
+// Package fcmv1 sends messages through the FCM HTTP v1 API
+// (https://fcm.googleapis.com/v1/projects/{project_id}/messages:send),
+// which replaces the legacy API's numeric multicast_id/results[] shape
+// with OAuth2 bearer-token auth and a single-message-per-request model.
+package fcmv1
+
+import "encoding/json"
+
+// Message is a single FCM HTTP v1 message. It targets exactly one of
+// Token, Topic, or Condition.
+type Message struct {
+	Token        string            `json:"token,omitempty"`
+	Topic        string            `json:"topic,omitempty"`
+	Condition    string            `json:"condition,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+	Notification *Notification     `json:"notification,omitempty"`
+
+	// Android, APNS, and Webpush carry platform-specific overrides.
+	// They're left as raw JSON since their schemas are large and
+	// independent of how this package sends and decodes messages.
+	Android json.RawMessage `json:"android,omitempty"`
+	APNS    json.RawMessage `json:"apns,omitempty"`
+	Webpush json.RawMessage `json:"webpush,omitempty"`
+}
+
+// Notification is the v1 API's platform-independent notification
+// payload.
+type Notification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Image string `json:"image,omitempty"`
+}
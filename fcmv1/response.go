@@ -0,0 +1,70 @@
+package fcmv1
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/evrenios/go-fcm"
+)
+
+// Result is the outcome of a single FCM HTTP v1 send request.
+type Result struct {
+	// Name is the resource name of the created message, e.g.
+	// "projects/{project_id}/messages/{message_id}", set on success.
+	Name string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Error is an *fcm.FCMError wrapping one of the fcm package's
+	// sentinel errors (match it with errors.Is), or nil on success.
+	Error error
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding either the
+// `{"name": "..."}` success body or the
+// `{"error": {"code", "status", "message", "details"}}` failure body
+// returned by the v1 endpoint.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var body struct {
+		Name  string `json:"name"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Status  string `json:"status"`
+			Message string `json:"message"`
+			Details []struct {
+				Type      string `json:"@type"`
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	r.Name = body.Name
+	if body.Error == nil {
+		return nil
+	}
+
+	// The canonical FCM error code lives in details[].errorCode; fall
+	// back to the gRPC status if FCM didn't supply one.
+	code := body.Error.Status
+	var details []fcm.ErrorDetail
+	for _, d := range body.Error.Details {
+		details = append(details, fcm.ErrorDetail{Type: d.Type, ErrorCode: d.ErrorCode})
+		if d.ErrorCode != "" {
+			code = d.ErrorCode
+		}
+	}
+	r.Error = fcm.NewError(code, 0, body.Error.Message, details)
+
+	return nil
+}
+
+// Unregistered reports whether the token this Result was sent to
+// should be dropped, mirroring fcm.Result.Unregistered.
+func (r Result) Unregistered() bool {
+	return errors.Is(r.Error, fcm.ErrNotRegistered) || errors.Is(r.Error, fcm.ErrMismatchSenderID)
+}
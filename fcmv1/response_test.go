@@ -0,0 +1,95 @@
+package fcmv1
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/evrenios/go-fcm"
+)
+
+func TestResultUnmarshalJSONSuccess(t *testing.T) {
+	var result Result
+	if err := json.Unmarshal([]byte(`{"name":"projects/p/messages/1"}`), &result); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if result.Name != "projects/p/messages/1" {
+		t.Fatalf("Name = %q, want %q", result.Name, "projects/p/messages/1")
+	}
+	if result.Error != nil {
+		t.Fatalf("Error = %v, want nil", result.Error)
+	}
+}
+
+func TestResultUnmarshalJSONErrorWithDetails(t *testing.T) {
+	body := `{
+		"error": {
+			"code": 404,
+			"status": "NOT_FOUND",
+			"message": "Requested entity was not found.",
+			"details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]
+		}
+	}`
+
+	var result Result
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !errors.Is(result.Error, fcm.ErrNotRegistered) {
+		t.Fatalf("Error = %v, want errors.Is(..., fcm.ErrNotRegistered)", result.Error)
+	}
+
+	var fe *fcm.FCMError
+	if !errors.As(result.Error, &fe) {
+		t.Fatalf("Error = %v, want *fcm.FCMError", result.Error)
+	}
+	if fe.Code != "UNREGISTERED" {
+		t.Fatalf("Code = %q, want %q", fe.Code, "UNREGISTERED")
+	}
+	if fe.Message != "Requested entity was not found." {
+		t.Fatalf("Message = %q, want the FCM message", fe.Message)
+	}
+	if len(fe.Details) != 1 || fe.Details[0].ErrorCode != "UNREGISTERED" {
+		t.Fatalf("Details = %+v, want one UNREGISTERED entry", fe.Details)
+	}
+}
+
+func TestResultUnmarshalJSONErrorWithoutDetailsFallsBackToStatus(t *testing.T) {
+	body := `{"error": {"code": 429, "status": "RESOURCE_EXHAUSTED", "message": "rate limited"}}`
+
+	var result Result
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	var fe *fcm.FCMError
+	if !errors.As(result.Error, &fe) {
+		t.Fatalf("Error = %v, want *fcm.FCMError", result.Error)
+	}
+	if fe.Code != "RESOURCE_EXHAUSTED" {
+		t.Fatalf("Code = %q, want the status fallback %q", fe.Code, "RESOURCE_EXHAUSTED")
+	}
+	if !errors.Is(result.Error, fcm.ErrUnknown) {
+		t.Fatalf("Error = %v, want errors.Is(..., fcm.ErrUnknown) for an unrecognized status", result.Error)
+	}
+}
+
+func TestResultUnregistered(t *testing.T) {
+	cases := []struct {
+		code string
+		want bool
+	}{
+		{"UNREGISTERED", true},
+		{"SENDER_ID_MISMATCH", true},
+		{"INTERNAL", false},
+	}
+
+	for _, tc := range cases {
+		result := Result{Error: fcm.NewError(tc.code, 0, "", nil)}
+		if got := result.Unregistered(); got != tc.want {
+			t.Errorf("Result{Error: %s}.Unregistered() = %v, want %v", tc.code, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package fcm
+
+// Message is a legacy FCM HTTP API message. It targets exactly one of
+// To (a single registration token), RegistrationIDs (a multicast send),
+// or Condition.
+type Message struct {
+	To                       string                 `json:"to,omitempty"`
+	RegistrationIDs          []string               `json:"registration_ids,omitempty"`
+	Condition                string                 `json:"condition,omitempty"`
+	CollapseKey              string                 `json:"collapse_key,omitempty"`
+	Priority                 string                 `json:"priority,omitempty"`
+	ContentAvailable         bool                   `json:"content_available,omitempty"`
+	MutableContent           bool                   `json:"mutable_content,omitempty"`
+	TimeToLive               *int                   `json:"time_to_live,omitempty"`
+	DeliveryReceiptRequested bool                   `json:"delivery_receipt_requested,omitempty"`
+	DryRun                   bool                   `json:"dry_run,omitempty"`
+	Data                     map[string]interface{} `json:"data,omitempty"`
+	Notification             *Notification          `json:"notification,omitempty"`
+}
+
+// Notification is the legacy FCM notification payload.
+type Notification struct {
+	Title        string `json:"title,omitempty"`
+	Body         string `json:"body,omitempty"`
+	Icon         string `json:"icon,omitempty"`
+	Sound        string `json:"sound,omitempty"`
+	Badge        string `json:"badge,omitempty"`
+	Tag          string `json:"tag,omitempty"`
+	Color        string `json:"color,omitempty"`
+	ClickAction  string `json:"click_action,omitempty"`
+	BodyLocKey   string `json:"body_loc_key,omitempty"`
+	BodyLocArgs  string `json:"body_loc_args,omitempty"`
+	TitleLocKey  string `json:"title_loc_key,omitempty"`
+	TitleLocArgs string `json:"title_loc_args,omitempty"`
+}
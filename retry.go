@@ -0,0 +1,226 @@
+package fcm
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls the backoff schedule used by
+// Client.SendWithRetryConfig.
+type RetryConfig struct {
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the computed backoff delay, before jitter is
+	// applied.
+	MaxDelay time.Duration
+
+	// Jitter is the fractional amount of randomness applied to each
+	// delay, in the range [0, 1). A delay of d with jitter j is drawn
+	// uniformly from [d*(1-j), d*(1+j)].
+	Jitter float64
+
+	// MaxElapsedTime bounds the total time spent retrying, starting
+	// from the first send. Zero means no limit.
+	MaxElapsedTime time.Duration
+}
+
+// DefaultRetryConfig is used by SendWithRetry.
+var DefaultRetryConfig = RetryConfig{
+	BaseDelay: time.Second,
+	MaxDelay:  time.Minute,
+	Jitter:    0.2,
+}
+
+// SendWithRetry sends msg, making at most maxAttempts attempts in total
+// (the first send included), using DefaultRetryConfig for backoff
+// timing. See SendWithRetryConfig.
+func (c *Client) SendWithRetry(msg *Message, maxAttempts int) (*Response, error) {
+	return c.SendWithRetryConfig(msg, maxAttempts, DefaultRetryConfig)
+}
+
+// SendWithRetryConfig sends msg, making at most maxAttempts attempts in
+// total (the first send included) and retrying when the transport error satisfies interface{ Temporary() bool } or when
+// a per-token Result.Error is retryable (see retryableResultError). For
+// a multicast message, only registration IDs that failed with a
+// retryable error are resent on each attempt; the returned Response
+// merges each token's most recently observed Result, so Success,
+// Failure, and CanonicalIDs reflect the final outcome across all
+// attempts.
+//
+// A 429 or 503 response's Retry-After header, if present, is used as a
+// floor for the next attempt's delay.
+func (c *Client) SendWithRetryConfig(msg *Message, maxAttempts int, cfg RetryConfig) (*Response, error) {
+	allIDs := targetIDs(msg)
+	latest := make(map[string]Result, len(allIDs))
+	pending := allIDs
+	start := time.Now()
+
+	var last *Response
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts && len(pending) > 0; attempt++ {
+		var header http.Header
+		last, header, lastErr = c.send(buildAttempt(msg, pending))
+
+		switch {
+		case lastErr != nil:
+			if !temporary(lastErr) {
+				pending = nil
+			}
+		case len(last.Results) == 0:
+			// Topic/condition sends report failures via the top-level
+			// Response.Error instead of per-Result errors.
+			for _, id := range pending {
+				latest[id] = Result{Error: last.Error}
+			}
+			if last.Error == nil || !retryableResultError(last.Error) {
+				pending = nil
+			}
+		default:
+			var next []string
+			for i, result := range last.Results {
+				if i >= len(pending) {
+					break
+				}
+				latest[pending[i]] = result
+				if result.Error != nil && retryableResultError(result.Error) {
+					next = append(next, pending[i])
+				}
+			}
+			pending = next
+		}
+
+		if attempt == maxAttempts-1 || len(pending) == 0 {
+			break
+		}
+		if cfg.MaxElapsedTime > 0 && time.Since(start) > cfg.MaxElapsedTime {
+			break
+		}
+
+		delay := backoffDelay(cfg, attempt)
+		if header != nil {
+			if ra, ok := parseRetryAfter(header.Get("Retry-After")); ok && ra > delay {
+				delay = ra
+			}
+		}
+		time.Sleep(delay)
+	}
+
+	merged := mergeResults(last, allIDs, latest)
+	c.notifyInvalidTokens(allIDs, merged)
+	return merged, lastErr
+}
+
+// buildAttempt returns a shallow copy of msg scoped to the given
+// registration IDs, preserving every other field.
+func buildAttempt(msg *Message, ids []string) *Message {
+	attempt := *msg
+	if len(msg.RegistrationIDs) > 0 {
+		attempt.RegistrationIDs = ids
+	} else {
+		attempt.RegistrationIDs = nil
+		attempt.To = ids[0]
+	}
+	return &attempt
+}
+
+// mergeResults rebuilds Results, Success, Failure, and CanonicalIDs
+// from the most recently observed Result for each of allIDs, restoring
+// their original order. last supplies the other fields (MulticastID,
+// MessageID, ...) when available, but a nil last (the final attempt
+// failed at the transport level) doesn't discard results gathered from
+// earlier, already-succeeded attempts.
+func mergeResults(last *Response, allIDs []string, latest map[string]Result) *Response {
+	if last == nil && len(latest) == 0 {
+		return nil
+	}
+
+	var merged Response
+	if last != nil {
+		merged = *last
+	}
+	merged.Results = make([]Result, len(allIDs))
+	merged.Success, merged.Failure, merged.CanonicalIDs = 0, 0, 0
+
+	for i, id := range allIDs {
+		result := latest[id]
+		merged.Results[i] = result
+
+		switch {
+		case result.Error != nil:
+			merged.Failure++
+		case result.RegistrationID != "":
+			merged.Success++
+			merged.CanonicalIDs++
+		default:
+			merged.Success++
+		}
+	}
+
+	return &merged
+}
+
+// temporary reports whether err has a Temporary() bool method, as
+// net.Error does, and that method returns true.
+func temporary(err error) bool {
+	te, ok := err.(interface{ Temporary() bool })
+	return ok && te.Temporary()
+}
+
+// retryableResultError reports whether a per-token FCM error makes
+// sense to retry.
+func retryableResultError(err error) bool {
+	switch {
+	case errors.Is(err, ErrUnavailable),
+		errors.Is(err, ErrInternalServerError),
+		errors.Is(err, ErrDeviceMessageRateExceeded),
+		errors.Is(err, ErrTopicsMessageRateExceeded):
+		return true
+	default:
+		return false
+	}
+}
+
+// backoffDelay computes the delay before the given retry attempt
+// (0-indexed), as base*2^attempt capped at MaxDelay and randomized by
+// Jitter.
+func backoffDelay(cfg RetryConfig, attempt int) time.Duration {
+	delay := cfg.BaseDelay << uint(attempt)
+	if cfg.MaxDelay > 0 && delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	if cfg.Jitter <= 0 {
+		return delay
+	}
+
+	spread := float64(delay) * cfg.Jitter
+	offset := (rand.Float64()*2 - 1) * spread
+	return time.Duration(float64(delay) + offset)
+}
+
+// parseRetryAfter parses a Retry-After header value in either the
+// delta-seconds or HTTP-date form (RFC 7231 section 7.1.3).
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
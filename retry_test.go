@@ -0,0 +1,156 @@
+package fcm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	d, ok := parseRetryAfter("120")
+	if !ok || d != 120*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = %v, %v; want 120s, true", "120", d, ok)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(90 * time.Second).UTC()
+	value := when.Format(http.TimeFormat)
+
+	d, ok := parseRetryAfter(value)
+	if !ok {
+		t.Fatalf("parseRetryAfter(%q) ok = false, want true", value)
+	}
+	if d <= 0 || d > 90*time.Second {
+		t.Fatalf("parseRetryAfter(%q) = %v, want ~90s", value, d)
+	}
+}
+
+func TestParseRetryAfterInvalid(t *testing.T) {
+	for _, value := range []string{"", "not-a-date", "-5"} {
+		if _, ok := parseRetryAfter(value); ok {
+			t.Errorf("parseRetryAfter(%q) ok = true, want false", value)
+		}
+	}
+}
+
+func TestBackoffDelayCapsAtMaxDelay(t *testing.T) {
+	cfg := RetryConfig{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	if d := backoffDelay(cfg, 10); d != cfg.MaxDelay {
+		t.Fatalf("backoffDelay(attempt 10) = %v, want capped at %v", d, cfg.MaxDelay)
+	}
+}
+
+func TestBackoffDelayExponential(t *testing.T) {
+	cfg := RetryConfig{BaseDelay: time.Second, MaxDelay: time.Hour}
+	if d := backoffDelay(cfg, 0); d != time.Second {
+		t.Fatalf("backoffDelay(attempt 0) = %v, want 1s", d)
+	}
+	if d := backoffDelay(cfg, 2); d != 4*time.Second {
+		t.Fatalf("backoffDelay(attempt 2) = %v, want 4s", d)
+	}
+}
+
+func TestMergeResultsOrdersByAllIDs(t *testing.T) {
+	last := &Response{MulticastID: 42}
+	latest := map[string]Result{
+		"b": {MessageID: "2"},
+		"a": {Error: ErrUnavailable},
+	}
+
+	merged := mergeResults(last, []string{"a", "b"}, latest)
+	if merged.MulticastID != 42 {
+		t.Fatalf("MulticastID = %d, want 42", merged.MulticastID)
+	}
+	if merged.Success != 1 || merged.Failure != 1 {
+		t.Fatalf("Success/Failure = %d/%d, want 1/1", merged.Success, merged.Failure)
+	}
+	if merged.Results[0].Error != ErrUnavailable || merged.Results[1].MessageID != "2" {
+		t.Fatalf("Results not restored in allIDs order: %+v", merged.Results)
+	}
+}
+
+func TestMergeResultsSurvivesNilLastResponse(t *testing.T) {
+	latest := map[string]Result{"a": {MessageID: "1"}}
+
+	merged := mergeResults(nil, []string{"a"}, latest)
+	if merged == nil {
+		t.Fatal("mergeResults(nil, ids, latest) = nil, want a Response built from latest")
+	}
+	if merged.Success != 1 {
+		t.Fatalf("Success = %d, want 1", merged.Success)
+	}
+}
+
+func TestMergeResultsNoDataReturnsNil(t *testing.T) {
+	if merged := mergeResults(nil, []string{"a"}, map[string]Result{}); merged != nil {
+		t.Fatalf("mergeResults(nil, ids, empty) = %+v, want nil", merged)
+	}
+}
+
+// TestSendWithRetryConfigResendsOnlyFailedSubset exercises the full
+// SendWithRetryConfig orchestration loop against an httptest.Server:
+// the first attempt fails one of two registration IDs with a retryable
+// error, and the test asserts the second request resends only that ID,
+// and that the final merged Response reports both tokens as
+// successful.
+func TestSendWithRetryConfigResendsOnlyFailedSubset(t *testing.T) {
+	var mu sync.Mutex
+	var requests []Message
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg Message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Fatalf("decode request body: %v", err)
+		}
+
+		mu.Lock()
+		requests = append(requests, msg)
+		attempt := len(requests)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if attempt == 1 {
+			json.NewEncoder(w).Encode(Response{
+				Results: []Result{
+					{MessageID: "1"},
+					{Error: ErrUnavailable},
+				},
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(Response{
+			Results: []Result{{MessageID: "2"}},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", WithEndpoint(srv.URL))
+	cfg := RetryConfig{BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	resp, err := client.SendWithRetryConfig(&Message{RegistrationIDs: []string{"a", "b"}}, 2, cfg)
+	if err != nil {
+		t.Fatalf("SendWithRetryConfig() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(requests) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(requests))
+	}
+	if got := requests[1].RegistrationIDs; len(got) != 1 || got[0] != "b" {
+		t.Fatalf("second request RegistrationIDs = %v, want only the previously-failed [b]", got)
+	}
+
+	if resp.Success != 2 || resp.Failure != 0 {
+		t.Fatalf("Success/Failure = %d/%d, want 2/0", resp.Success, resp.Failure)
+	}
+	if resp.Results[0].MessageID != "1" || resp.Results[1].MessageID != "2" {
+		t.Fatalf("Results = %+v, want message IDs [1, 2] in original registration-ID order", resp.Results)
+	}
+}
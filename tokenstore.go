@@ -0,0 +1,65 @@
+package fcm
+
+import "sync"
+
+// TokenStore tracks device registration tokens that FCM has reported
+// as invalid or replaced, so applications can prune or migrate them
+// without walking Response.Results themselves.
+type TokenStore interface {
+	// MarkInvalid records that token is no longer valid, for the given
+	// reason (e.g. ErrNotRegistered).
+	MarkInvalid(token string, reason error)
+
+	// Replace records that old should be replaced by new, as reported
+	// via Result.RegistrationID (a canonical registration ID).
+	Replace(old, new string)
+
+	// Invalid returns every token currently marked invalid.
+	Invalid() []string
+}
+
+// MemoryTokenStore is an in-memory TokenStore. The zero value is ready
+// to use.
+type MemoryTokenStore struct {
+	mu      sync.Mutex
+	invalid map[string]error
+}
+
+// MarkInvalid implements TokenStore.
+func (s *MemoryTokenStore) MarkInvalid(token string, reason error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.invalid == nil {
+		s.invalid = make(map[string]error)
+	}
+	s.invalid[token] = reason
+}
+
+// Replace implements TokenStore. It marks old invalid and, if new had
+// previously been marked invalid (e.g. from an earlier send), clears
+// that stale entry. Replacement reflects a canonical registration ID
+// FCM issued on an otherwise successful send, not an error, so old is
+// recorded with a nil reason rather than a fabricated one.
+func (s *MemoryTokenStore) Replace(old, new string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.invalid == nil {
+		s.invalid = make(map[string]error)
+	}
+	s.invalid[old] = nil
+	delete(s.invalid, new)
+}
+
+// Invalid implements TokenStore.
+func (s *MemoryTokenStore) Invalid() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := make([]string, 0, len(s.invalid))
+	for token := range s.invalid {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
@@ -0,0 +1,16 @@
+package fcm
+
+import "testing"
+
+func TestMemoryTokenStoreReplaceDoesNotFabricateReason(t *testing.T) {
+	var store MemoryTokenStore
+	store.Replace("old-token", "new-token")
+
+	invalid := store.Invalid()
+	if len(invalid) != 1 || invalid[0] != "old-token" {
+		t.Fatalf("Invalid() = %v, want [old-token]", invalid)
+	}
+	if reason := store.invalid["old-token"]; reason != nil {
+		t.Fatalf("reason for replaced token = %v, want nil", reason)
+	}
+}